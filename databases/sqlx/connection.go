@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	//_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
@@ -22,7 +23,12 @@ CREATE TABLE users (
     recovery char(36) NOT NULL DEFAULT "",
     digest blob NOT NULL,
     email varchar(255) NOT NULL UNIQUE,
-    location varchar(255) NOT NULL DEFAULT "UTC"
+    location varchar(255) NOT NULL DEFAULT "UTC",
+    bio text NOT NULL DEFAULT "",
+    avatar varchar(255) NOT NULL DEFAULT "",
+    website varchar(255) NOT NULL DEFAULT "",
+    twitter varchar(255) NOT NULL DEFAULT "",
+    github varchar(255) NOT NULL DEFAULT ""
 );
 
 CREATE TABLE posts (
@@ -61,7 +67,12 @@ CREATE TABLE "users" (
     "recovery" char(36) NOT NULL DEFAULT '',
     "digest" bytea NOT NULL,
     "email" varchar(255) NOT NULL UNIQUE,
-    "location" varchar(255) NOT NULL DEFAULT 'UTC'
+    "location" varchar(255) NOT NULL DEFAULT 'UTC',
+    "bio" text NOT NULL DEFAULT '',
+    "avatar" varchar(255) NOT NULL DEFAULT '',
+    "website" varchar(255) NOT NULL DEFAULT '',
+    "twitter" varchar(255) NOT NULL DEFAULT '',
+    "github" varchar(255) NOT NULL DEFAULT ''
 );
 
 CREATE TABLE "posts" (
@@ -93,6 +104,38 @@ CREATE TABLE "settings" (
     "mailerhostname" varchar(255)
 );`
 
+// Migrations bring databases created by earlier versions up to date with the schema above.
+// Each statement is run separately and its error is ignored, as on up to date databases
+// the statements fail at already existing columns.
+// The views table is only created here, so that existing databases get it as well.
+var sqlite3Migrations = []string{
+	`ALTER TABLE users ADD COLUMN bio text NOT NULL DEFAULT ""`,
+	`ALTER TABLE users ADD COLUMN avatar varchar(255) NOT NULL DEFAULT ""`,
+	`ALTER TABLE users ADD COLUMN website varchar(255) NOT NULL DEFAULT ""`,
+	`ALTER TABLE users ADD COLUMN twitter varchar(255) NOT NULL DEFAULT ""`,
+	`ALTER TABLE users ADD COLUMN github varchar(255) NOT NULL DEFAULT ""`,
+	`CREATE TABLE IF NOT EXISTS views (
+    id integer NOT NULL PRIMARY KEY,
+    post integer NOT NULL,
+    created integer unsigned NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS views_created ON views (created)`,
+}
+
+var postgresMigrations = []string{
+	`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "bio" text NOT NULL DEFAULT ''`,
+	`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "avatar" varchar(255) NOT NULL DEFAULT ''`,
+	`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "website" varchar(255) NOT NULL DEFAULT ''`,
+	`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "twitter" varchar(255) NOT NULL DEFAULT ''`,
+	`ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "github" varchar(255) NOT NULL DEFAULT ''`,
+	`CREATE TABLE IF NOT EXISTS "views" (
+    "id" serial NOT NULL PRIMARY KEY,
+    "post" integer NOT NULL,
+    "created" integer NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS "views_created" ON "views" ("created")`,
+}
+
 // var mysql = `
 // CREATE DATABASE vertigo;
 // USE vertigo;
@@ -124,6 +167,7 @@ CREATE TABLE "settings" (
 func Drop() {
 	db.MustExec("DROP TABLE users")
 	db.MustExec("DROP TABLE posts")
+	db.MustExec("DROP TABLE views")
 	db.MustExec("DROP TABLE settings")
 	os.Remove("vertigo.db")
 }
@@ -138,22 +182,30 @@ func connect(driver, source string) {
 	}
 
 	var schema string
+	var migrations []string
 	switch driver {
 	case "sqlite3":
 		schema = sqlite3
+		migrations = sqlite3Migrations
 	/*case "mysql":
 	schema = mysql*/
 	case "postgres":
 		schema = postgres
+		migrations = postgresMigrations
 	}
 
 	conn.Exec(schema)
+	for _, migration := range migrations {
+		conn.Exec(migration)
+	}
 
 	log.Println("sqlx: using", driver)
 
 	db = conn
 
 	Settings = VertigoSettings()
+
+	go pruneViews(time.Hour)
 }
 
 func init() {
@@ -3,6 +3,8 @@
 // The package contains three files:
 // * connection.go, which handles the actual database connection as singleton
 // * posts.go, which handles CRUD methods for posts
+// * lint.go, which handles Markdown warnings for posts
+// * seo.go, which handles SEO scoring for posts
 // * users.go, which handles CRUD methods for users
 // * email.go, which handles method for sending email to users
 // * settings.go, which handles CU methods for settings
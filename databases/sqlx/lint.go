@@ -0,0 +1,108 @@
+package sqlx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	fence          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	referenceLink  = regexp.MustCompile(`(^|[^\w\]])\[([^\]]+)\]\[([^\]]*)\]`)
+	referenceDef   = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:\s*\S+`)
+	tableSeparator = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	codeSpan       = regexp.MustCompile("``.*?``|`[^`]*`")
+)
+
+// Lint or post.Lint runs a best-effort pass over post.Markdown and returns warnings
+// for constructs which are likely to render oddly, such as unclosed code fences,
+// reference links without a definition and table rows with a wrong number of cells.
+// The checks are heuristic, so the warnings are never meant to block saving a post.
+func (post Post) Lint() []string {
+	warnings := make([]string, 0)
+	lines := strings.Split(strings.Replace(post.Markdown, "\r\n", "\n", -1), "\n")
+
+	definitions := make(map[string]bool)
+	for _, line := range lines {
+		if m := referenceDef.FindStringSubmatch(line); m != nil {
+			definitions[strings.ToLower(m[1])] = true
+		}
+	}
+
+	// opening holds the fence which started the current code block, or an empty
+	// string when outside of one. Closing fence has to use the same character
+	// and be at least as long as the opening one.
+	var opening string
+	var openedAt int
+	var columns int
+	for i, line := range lines {
+		if m := fence.FindStringSubmatch(line); m != nil {
+			if opening == "" {
+				opening = m[1]
+				openedAt = i + 1
+				columns = 0
+				continue
+			}
+			if m[1][0] == opening[0] && len(m[1]) >= len(opening) && strings.TrimSpace(line) == m[1] {
+				opening = ""
+			}
+			continue
+		}
+		if opening != "" {
+			continue
+		}
+		// indented code blocks are rendered as is
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		// brackets right after a word, such as x[0][1], are most likely not meant as links
+		for _, m := range referenceLink.FindAllStringSubmatch(codeSpan.ReplaceAllString(line, ""), -1) {
+			ref := m[3]
+			if ref == "" {
+				ref = m[2]
+			}
+			if !definitions[strings.ToLower(ref)] {
+				warnings = append(warnings, fmt.Sprintf("line %d: reference link [%s] is not defined", i+1, ref))
+			}
+		}
+
+		// tables are recognized by their header separator row, after which every
+		// row should have as many cells as the header. Like in blackfriday, the
+		// table ends at the first line without a pipe.
+		// Separator without a pipe is a setext heading or a horizontal rule instead.
+		if i > 0 && strings.Contains(line, "|") && strings.Contains(lines[i-1], "|") && tableSeparator.MatchString(line) {
+			columns = cells(lines[i-1])
+			if cells(line) != columns {
+				warnings = append(warnings, fmt.Sprintf("line %d: table separator has %d columns, header has %d", i+1, cells(line), columns))
+			}
+			continue
+		}
+		if columns > 0 {
+			if !strings.Contains(line, "|") {
+				columns = 0
+				continue
+			}
+			if n := cells(line); n != columns {
+				warnings = append(warnings, fmt.Sprintf("line %d: table row has %d columns, header has %d", i+1, n, columns))
+			}
+		}
+	}
+
+	if opening != "" {
+		warnings = append(warnings, fmt.Sprintf("line %d: code fence %s is never closed", openedAt, opening))
+	}
+	return warnings
+}
+
+// cells returns the number of cells in a Markdown table row.
+// Leading and trailing pipes are optional, so they are not counted.
+// Neither are escaped pipes and pipes inside code spans, as they are part of the cell content.
+func cells(row string) int {
+	row = codeSpan.ReplaceAllString(row, "")
+	row = strings.Replace(row, `\|`, "", -1)
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	return strings.Count(row, "|") + 1
+}
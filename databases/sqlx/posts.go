@@ -16,24 +16,27 @@ import (
 // JSON field after type refer to JSON key which martini will use to render data.
 // Form field refers to frontend POST form `name` fields which martini uses to read data from.
 // Binding defines whether the field is required when inserting or updating the object.
+// Warnings is not stored in the database, it only carries the results of post.Lint back to the client.
 type Post struct {
-	ID         int64  `json:"id"`
-	Title      string `json:"title" form:"title" binding:"required"`
-	Content    string `json:"content"`
-	Markdown   string `json:"markdown" form:"markdown"`
-	Slug       string `json:"slug"`
-	Author     int64  `json:"author"`
-	Excerpt    string `json:"excerpt"`
-	Viewcount  uint   `json:"viewcount"`
-	Published  bool   `json:"-"`
-	Created    int64  `json:"created"`
-	Updated    int64  `json:"updated"`
-	TimeOffset int    `json:"timeoffset"`
+	ID         int64    `json:"id"`
+	Title      string   `json:"title" form:"title" binding:"required"`
+	Content    string   `json:"content"`
+	Markdown   string   `json:"markdown" form:"markdown"`
+	Slug       string   `json:"slug"`
+	Author     int64    `json:"author"`
+	Excerpt    string   `json:"excerpt"`
+	Viewcount  uint     `json:"viewcount"`
+	Published  bool     `json:"-"`
+	Created    int64    `json:"created"`
+	Updated    int64    `json:"updated"`
+	TimeOffset int      `json:"timeoffset"`
+	Warnings   []string `json:"warnings,omitempty" db:"-"`
 }
 
 // Insert or post.Insert inserts Post object into database.
 // Requires active session cookie
 // Fills post.Author, post.Created, post.Edited, post.Excerpt, post.Slug and post.Published automatically.
+// Markdown warnings found by post.Lint are returned in post.Warnings and do not prevent saving.
 // Returns Post and error object.
 func (post Post) Insert(user User) (Post, error) {
 	_, offset, err := timezone.Offset(user.Location)
@@ -49,6 +52,7 @@ func (post Post) Insert(user User) (Post, error) {
 	post.Slug = slug.Create(post.Title)
 	post.Published = false
 	post.Viewcount = 0
+	post.Warnings = post.Lint()
 	_, err = db.NamedExec(`INSERT INTO posts (title, content, markdown, slug, author, excerpt, viewcount, published, created, updated, timeoffset)
 		VALUES (:title, :content, :markdown, :slug, :author, :excerpt, :viewcount, :published, :created, :updated, :timeoffset)`, post)
 	if err != nil {
@@ -94,6 +98,7 @@ func (post Post) Update(entry Post) (Post, error) {
 	entry.Created = post.Created
 	entry.TimeOffset = post.TimeOffset
 	entry.Author = post.Author
+	entry.Warnings = entry.Lint()
 	return entry, nil
 }
 
@@ -110,7 +115,11 @@ func (post Post) Unpublish() error {
 // Requires session cookie.
 // Returns error object.
 func (post Post) Delete() error {
-	_, err := db.NamedExec("DELETE FROM posts WHERE id = :id", post)
+	_, err := db.NamedExec("DELETE FROM views WHERE post = :id", post)
+	if err != nil {
+		return err
+	}
+	_, err = db.NamedExec("DELETE FROM posts WHERE id = :id", post)
 	if err != nil {
 		return err
 	}
@@ -139,12 +148,61 @@ func (post Post) GetAll() ([]Post, error) {
 	return posts, nil
 }
 
-// Update or user.Update updates parameter "entry" with data given in parameter "user".
-// Returns updated Ad object and an error object.
+// ViewRetention is how long individual views are kept in the view log.
+// It is also the longest window GetTrending can be called with.
+const ViewRetention = 30 * 24 * time.Hour
+
+// Increment or post.Increment adds one to post.Viewcount and records the view with a timestamp
+// for GetTrending.
+// This function is supposed to be run as goroutine, so errors are only logged.
 func (post Post) Increment() {
 	post.Viewcount += 1
 	_, err := db.NamedExec("UPDATE posts SET viewcount = :viewcount WHERE id = :id", post)
 	if err != nil {
 		log.Println("analytics error:", err)
 	}
+	_, err = db.NamedExec("INSERT INTO views (post, created) VALUES (:post, :created)",
+		map[string]interface{}{"post": post.ID, "created": time.Now().UTC().Unix()})
+	if err != nil {
+		log.Println("analytics error:", err)
+	}
+}
+
+// pruneViews deletes views older than ViewRetention from the view log every interval.
+// This function is supposed to be run as goroutine, as it never returns.
+func pruneViews(interval time.Duration) {
+	for range time.Tick(interval) {
+		created := time.Now().UTC().Add(-ViewRetention).Unix()
+		_, err := db.NamedExec("DELETE FROM views WHERE created < :created",
+			map[string]interface{}{"created": created})
+		if err != nil {
+			log.Println("analytics error:", err)
+		}
+	}
+}
+
+// GetTrending or post.GetTrending returns published posts with the most views during the last
+// window, most viewed first. Unlike Viewcount, only views recorded in the view log are counted,
+// so window has to be positive and at most ViewRetention. Limit has to be at least one.
+// Returns []Post and error object.
+func (post Post) GetTrending(window time.Duration, limit int) ([]Post, error) {
+	posts := make([]Post, 0)
+	if window <= 0 || window > ViewRetention {
+		return posts, errors.New("window out of range")
+	}
+	if limit < 1 {
+		return posts, errors.New("limit out of range")
+	}
+	since := time.Now().UTC().Add(-window).Unix()
+	stmt, err := db.PrepareNamed(`SELECT posts.* FROM posts JOIN views ON views.post = posts.id
+		WHERE posts.published = :published AND views.created >= :since
+		GROUP BY posts.id ORDER BY COUNT(views.id) DESC, posts.created DESC LIMIT :limit`)
+	if err != nil {
+		return posts, err
+	}
+	err = stmt.Select(&posts, map[string]interface{}{"published": true, "since": since, "limit": limit})
+	if err != nil {
+		return posts, err
+	}
+	return posts, nil
 }
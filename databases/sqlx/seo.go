@@ -0,0 +1,169 @@
+package sqlx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SEO struct holds the heuristic search engine optimization score of a post.
+// Score is the sum of the scores of all Criteria and Max is the highest possible score.
+type SEO struct {
+	Score    int            `json:"score"`
+	Max      int            `json:"max"`
+	Criteria []SEOCriterion `json:"criteria"`
+}
+
+// SEOCriterion struct holds the result of a single SEO check.
+// Rule explains how the criterion is scored and Result explains how the post did.
+type SEOCriterion struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Max    int    `json:"max"`
+	Rule   string `json:"rule"`
+	Result string `json:"result"`
+}
+
+// seoCriterionMax is the score given for a fully satisfied criterion.
+const seoCriterionMax = 20
+
+// SEOScore or post.SEOScore calculates a heuristic SEO score for the post from its title,
+// excerpt and rendered content. The excerpt, which is made of the first 15 words of the post,
+// is rendered as the meta description of the post page.
+// The checks are meant as writing aid only and do not reflect any search engine's ranking.
+// Returns SEO and error object.
+func (post Post) SEOScore() (SEO, error) {
+	var seo SEO
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(post.Content))
+	if err != nil {
+		return seo, err
+	}
+
+	seo.Criteria = []SEOCriterion{
+		seoTitle(post.Title),
+		seoDescription(post.Excerpt),
+		seoHeadings(doc),
+		seoInternalLinks(doc),
+		seoImageAlt(doc),
+	}
+	for _, criterion := range seo.Criteria {
+		seo.Score += criterion.Score
+		seo.Max += criterion.Max
+	}
+	return seo, nil
+}
+
+func seoTitle(title string) SEOCriterion {
+	c := SEOCriterion{
+		Name: "title",
+		Max:  seoCriterionMax,
+		Rule: "Full score for a title of 30 to 60 characters, half for 10 to 70 characters.",
+	}
+	n := utf8.RuneCountInString(strings.TrimSpace(title))
+	switch {
+	case n >= 30 && n <= 60:
+		c.Score = c.Max
+	case n >= 10 && n <= 70:
+		c.Score = c.Max / 2
+	}
+	c.Result = fmt.Sprintf("Title is %d characters long.", n)
+	return c
+}
+
+func seoDescription(excerpt string) SEOCriterion {
+	c := SEOCriterion{
+		Name: "description",
+		Max:  seoCriterionMax,
+		Rule: "Full score for a meta description of 50 to 160 characters, half for any other non-empty one. The meta description is made of the first 15 words of the post.",
+	}
+	n := utf8.RuneCountInString(strings.TrimSpace(excerpt))
+	switch {
+	case n >= 50 && n <= 160:
+		c.Score = c.Max
+	case n > 0:
+		c.Score = c.Max / 2
+	}
+	c.Result = fmt.Sprintf("Meta description is %d characters long.", n)
+	return c
+}
+
+func seoHeadings(doc *goquery.Document) SEOCriterion {
+	c := SEOCriterion{
+		Name: "headings",
+		Max:  seoCriterionMax,
+		Rule: "Full score when the content has at least one subheading (h2 to h6) and no h1, as the post title is already rendered as one.",
+	}
+	h1 := doc.Find("h1").Length()
+	subheadings := doc.Find("h2, h3, h4, h5, h6").Length()
+	switch {
+	case subheadings > 0 && h1 == 0:
+		c.Score = c.Max
+	case subheadings > 0 || h1 > 0:
+		c.Score = c.Max / 2
+	}
+	c.Result = fmt.Sprintf("Content has %d subheadings and %d h1 headings.", subheadings, h1)
+	return c
+}
+
+func seoInternalLinks(doc *goquery.Document) SEOCriterion {
+	c := SEOCriterion{
+		Name: "internal links",
+		Max:  seoCriterionMax,
+		Rule: "Full score when the content links to at least one other page on this site.",
+	}
+	n := 0
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		if internal(href) {
+			n++
+		}
+	})
+	if n > 0 {
+		c.Score = c.Max
+	}
+	c.Result = fmt.Sprintf("Content has %d internal links.", n)
+	return c
+}
+
+// internal checks whether href points to another page on this site, either with a relative
+// URL or with an absolute one on the same host as Settings.Hostname.
+func internal(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		// fragment only links point to the post itself
+		return u.Path != ""
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	site, err := url.Parse(Settings.Hostname)
+	if err != nil || site.Host == "" {
+		return false
+	}
+	return strings.EqualFold(u.Host, site.Host)
+}
+
+func seoImageAlt(doc *goquery.Document) SEOCriterion {
+	c := SEOCriterion{
+		Name: "image alt text",
+		Max:  seoCriterionMax,
+		Rule: "Score is the share of images with alt text. Full score when the content has no images.",
+	}
+	images := doc.Find("img")
+	described := images.FilterFunction(func(i int, s *goquery.Selection) bool {
+		return strings.TrimSpace(s.AttrOr("alt", "")) != ""
+	}).Length()
+	if images.Length() == 0 {
+		c.Score = c.Max
+	} else {
+		c.Score = c.Max * described / images.Length()
+	}
+	c.Result = fmt.Sprintf("%d of %d images have alt text.", described, images.Length())
+	return c
+}
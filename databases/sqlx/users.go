@@ -12,6 +12,7 @@ import (
 // User struct holds all relevant data for representing user accounts on Vertigo.
 // A complete User struct also includes Posts field (type []Post) which includes
 // all posts made by the user.
+// Bio, Avatar, Website, Twitter and Github make up the public author profile.
 type User struct {
 	ID       int64  `json:"id"`
 	Name     string `json:"name" form:"name"`
@@ -21,6 +22,11 @@ type User struct {
 	Email    string `json:"email" form:"email" binding:"required"`
 	Posts    []Post `json:"posts"`
 	Location string `json:"location" form:"location"`
+	Bio      string `json:"bio" form:"bio"`
+	Avatar   string `json:"avatar" form:"avatar"`
+	Website  string `json:"website" form:"website"`
+	Twitter  string `json:"twitter" form:"twitter"`
+	Github   string `json:"github" form:"github"`
 }
 
 // GenerateHash generates bcrypt hash from plaintext password
@@ -60,11 +66,11 @@ func (user User) Login() (User, error) {
 }
 
 // Update or user.Update updates data of "entry" parameter with the data received from "user".
-// Can only used to update Name and Digest fields because of how user.Get works.
-// Currently not used elsewhere than in password Recovery, that's why the Digest generation.
+// Can only used to update Name, Digest and profile fields because of how user.Get works.
+// Used in password Recovery and when updating the author profile.
 func (user User) Update(entry User) (User, error) {
 	_, err := db.NamedExec(
-		"UPDATE users SET name = :name, digest = :digest, location = :location, recovery = :recovery WHERE id = :id",
+		"UPDATE users SET name = :name, digest = :digest, location = :location, recovery = :recovery, bio = :bio, avatar = :avatar, website = :website, twitter = :twitter, github = :github WHERE id = :id",
 		entry)
 	if err != nil {
 		return entry, err
@@ -156,6 +162,39 @@ func (user User) Get() (User, error) {
 	return user, nil
 }
 
+// Profile or user.Profile returns user according to given user.ID without post data merged.
+// Returns User and error object.
+func (user User) Profile() (User, error) {
+	stmt, err := db.PrepareNamed("SELECT * FROM users WHERE id = :id")
+	if err != nil {
+		return user, err
+	}
+	err = stmt.Get(&user, user)
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return user, errors.New("not found")
+		}
+		return user, err
+	}
+	return user, nil
+}
+
+// PublishedPosts or user.PublishedPosts returns published posts of the user, newest first.
+// Parameters limit and offset are used for paginating the results.
+// Returns []Post and error object.
+func (user User) PublishedPosts(limit, offset int) ([]Post, error) {
+	posts := make([]Post, 0)
+	stmt, err := db.PrepareNamed("SELECT * FROM posts WHERE author = :id AND published = :published ORDER BY created DESC LIMIT :limit OFFSET :offset")
+	if err != nil {
+		return posts, err
+	}
+	err = stmt.Select(&posts, map[string]interface{}{"id": user.ID, "published": true, "limit": limit, "offset": offset})
+	if err != nil {
+		return posts, err
+	}
+	return posts, nil
+}
+
 // GetByEmail or user.GetByEmail returns User object according to given .Email
 // with post information merged.
 func (user User) GetByEmail() (User, error) {
@@ -189,6 +228,7 @@ func (user User) GetByEmail() (User, error) {
 
 // Insert or user.Insert inserts a new User struct into the database.
 // The function creates .Digest hash from .Password.
+// Profile fields are left empty, they are set afterwards with user.Update.
 func (user User) Insert() (User, error) {
 	digest, err := GenerateHash(user.Password)
 	if err != nil {
@@ -199,6 +239,11 @@ func (user User) Insert() (User, error) {
 		return user, errors.New("user location invalid")
 	}
 	user.Digest = digest
+	user.Bio = ""
+	user.Avatar = ""
+	user.Website = ""
+	user.Twitter = ""
+	user.Github = ""
 	_, err = db.NamedExec("INSERT INTO users (name, digest, email, location) VALUES (:name, :digest, :email, :location)", user)
 	if err != nil {
 		if err.Error() == "UNIQUE constraint failed: users.email" || err.Error() == `pq: duplicate key value violates unique constraint "users_email_key"` {
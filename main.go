@@ -208,6 +208,47 @@ func bindUser(next http.Handler) http.Handler {
 	return http.HandlerFunc(fn)
 }
 
+func bindProfile(next http.Handler) http.Handler {
+
+	fn := func(w http.ResponseWriter, r *http.Request) {
+
+		var profile Profile
+
+		if r.Header["Content-Type"][0] == "application/json" {
+			decoder := json.NewDecoder(r.Body)
+			err := decoder.Decode(&profile)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			context.Set(r, "profile", profile)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r.ParseForm()
+
+		profile.Name = postFormPointer(r, "name")
+		profile.Bio = postFormPointer(r, "bio")
+		profile.Avatar = postFormPointer(r, "avatar")
+		profile.Website = postFormPointer(r, "website")
+		profile.Twitter = postFormPointer(r, "twitter")
+		profile.Github = postFormPointer(r, "github")
+		context.Set(r, "profile", profile)
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// postFormPointer returns pointer to POST form value of key, or nil if the form has no such field.
+func postFormPointer(r *http.Request, key string) *string {
+	if _, ok := r.PostForm[key]; !ok {
+		return nil
+	}
+	value := r.PostFormValue(key)
+	return &value
+}
+
 func bindReset(next http.Handler) http.Handler {
 
 	fn := func(w http.ResponseWriter, r *http.Request) {
@@ -277,6 +318,7 @@ func NewServer() *vestigo.Router {
 	postSearch := alice.New(bindSearch)
 	postReset := alice.New(bindReset)
 	postSettings := alice.New(session, bindSettings)
+	postProfile := alice.New(session, ProtectedPage, bindProfile)
 	sessionRedirect := alice.New(session, SessionRedirect)
 
 	r := vestigo.NewRouter()
@@ -309,6 +351,7 @@ func NewServer() *vestigo.Router {
 
 	r.Get("/user", protectedHandler.Then(http.HandlerFunc(ReadUser)).(http.HandlerFunc))
 	//r.HandleFunc("/delete", ProtectedPage, binding.Form(User{}), DeleteUser)
+	r.Post("/user/profile", postProfile.ThenFunc(UpdateProfile).(http.HandlerFunc))
 	r.Get("/user/settings", protectedHandler.ThenFunc(ReadSettings).(http.HandlerFunc))
 	r.Post("/user/settings", postSettings.ThenFunc(UpdateSettings).(http.HandlerFunc))
 
@@ -338,6 +381,8 @@ func NewServer() *vestigo.Router {
 	r.Post("/user/login", recoverUser.ThenFunc(LoginUser).(http.HandlerFunc))
 	r.Get("/user/logout", LogoutUser)
 
+	r.Get("/author/:id", AuthorPage)
+
 	r.Get("/api", func(w http.ResponseWriter, r *http.Request) {
 		render.R.HTML(w, 200, "api/index", nil)
 	})
@@ -353,15 +398,20 @@ func NewServer() *vestigo.Router {
 	r.Post("/api/user", postUser.ThenFunc(CreateUser).(http.HandlerFunc))
 	r.Post("/api/user/login", recoverUser.ThenFunc(LoginUser).(http.HandlerFunc))
 	r.Post("/api/user/recover", recoverUser.ThenFunc(RecoverUser).(http.HandlerFunc))
+	r.Post("/api/user/profile", postProfile.ThenFunc(UpdateProfile).(http.HandlerFunc))
 	r.Post("/api/user/reset/:id/:recovery", postReset.ThenFunc(ResetUserPassword).(http.HandlerFunc))
 
+	r.Get("/api/author/:id", AuthorPage)
+
 	r.Post("/api/posts/search", postSearch.ThenFunc(SearchPost).(http.HandlerFunc))
 	r.Get("/api/posts", ReadPosts)
+	r.Get("/api/trending", ReadTrending)
 	r.Post("/api/post", postForm.ThenFunc(CreatePost).(http.HandlerFunc))
 	r.Post("/api/post/:slug/edit", postForm.ThenFunc(UpdatePost).(http.HandlerFunc))
 	r.Get("/api/post/:slug/delete", protectedHandler.ThenFunc(DeletePost).(http.HandlerFunc))
 	r.Get("/api/post/:slug/publish", protectedHandler.ThenFunc(PublishPost).(http.HandlerFunc))
 	r.Get("/api/post/:slug/unpublish", protectedHandler.ThenFunc(UnpublishPost).(http.HandlerFunc))
+	r.Get("/api/post/:slug/seo", protectedHandler.ThenFunc(ReadPostSEO).(http.HandlerFunc))
 	r.Get("/api/post/:slug", ReadPost)
 
 	return r
@@ -111,6 +111,14 @@ func TestEmptyAPIRoutes(t *testing.T) {
 			So(recorder.Body.String(), ShouldEqual, "[]")
 		})
 
+		Convey("trending page should return []", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/trending", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			So(recorder.Body.String(), ShouldEqual, "[]")
+		})
+
 		Convey("get post should return 404", func() {
 			request, _ := http.NewRequest("GET", "/api/user/0", nil)
 			server.ServeHTTP(recorder, request)
@@ -611,6 +619,57 @@ func TestPostListing(t *testing.T) {
 	})
 }
 
+func TestTrending(t *testing.T) {
+
+	Convey("using API", t, func() {
+
+		Convey("recently viewed post should be listed on /api/trending", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/trending?window=1h&limit=5", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			var posts []Post
+			json.Unmarshal(recorder.Body.Bytes(), &posts)
+			So(len(posts), ShouldEqual, 1)
+			So(posts[0].ID, ShouldEqual, post.ID)
+			So(posts[0].Slug, ShouldEqual, post.Slug)
+		})
+
+		Convey("malformed window should return 400", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/trending?window=foo", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 400)
+		})
+
+		Convey("window longer than view retention should return 400", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/trending?window=1000h", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 400)
+		})
+
+		Convey("malformed limit should return 400", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/trending?limit=foo", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 400)
+		})
+	})
+
+	Convey("GetTrending should reject window and limit out of range", t, func() {
+		var p Post
+		_, err := p.GetTrending(0, 10)
+		So(err, ShouldNotBeNil)
+		_, err = p.GetTrending(ViewRetention+time.Hour, 10)
+		So(err, ShouldNotBeNil)
+		_, err = p.GetTrending(time.Hour, 0)
+		So(err, ShouldNotBeNil)
+		_, err = p.GetTrending(time.Hour, -1)
+		So(err, ShouldNotBeNil)
+	})
+}
+
 func TestPostOwner(t *testing.T) {
 
 	Convey("using API", t, func() {
@@ -643,6 +702,171 @@ func TestPostOwner(t *testing.T) {
 	})
 }
 
+func TestUpdateProfile(t *testing.T) {
+
+	Convey("using API", t, func() {
+
+		Convey("should return 401 without authorization", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/api/user/profile", strings.NewReader(`{"bio": "Writes about Go."}`))
+			request.Header.Set("Content-Type", "application/json")
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 401)
+			So(recorder.Body.String(), ShouldEqual, `{"error":"Unauthorized"}`)
+		})
+
+		Convey("should return 200 with authorization", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/api/user/profile", strings.NewReader(`{"bio": "Writes about Go.", "github": "foo"}`))
+			cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+			request.AddCookie(cookie)
+			request.Header.Set("Content-Type", "application/json")
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			var u User
+			json.Unmarshal(recorder.Body.Bytes(), &u)
+			So(u.ID, ShouldEqual, user.ID)
+			So(u.Name, ShouldEqual, user.Name)
+			So(u.Bio, ShouldEqual, "Writes about Go.")
+			So(u.Github, ShouldEqual, "foo")
+		})
+
+		Convey("should only update fields present in the payload", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/api/user/profile", strings.NewReader(`{"website": "https://example.com"}`))
+			cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+			request.AddCookie(cookie)
+			request.Header.Set("Content-Type", "application/json")
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			var u User
+			json.Unmarshal(recorder.Body.Bytes(), &u)
+			So(u.Name, ShouldEqual, user.Name)
+			So(u.Website, ShouldEqual, "https://example.com")
+			So(u.Bio, ShouldEqual, "Writes about Go.")
+			So(u.Github, ShouldEqual, "foo")
+		})
+	})
+
+	Convey("using frontend", t, func() {
+
+		Convey("should return 302 with authorization", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/user/profile", strings.NewReader(`bio=Writes+about+Go.&github=foo`))
+			cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+			request.AddCookie(cookie)
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 302)
+		})
+	})
+}
+
+func TestAuthorPage(t *testing.T) {
+
+	Convey("using API", t, func() {
+
+		Convey("should return profile with published posts", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", fmt.Sprintf("/api/author/%d", user.ID), nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			So(recorder.Body.String(), ShouldNotContainSubstring, `"email"`)
+			var a struct {
+				User
+				Page int `json:"page"`
+				Next int `json:"next"`
+			}
+			json.Unmarshal(recorder.Body.Bytes(), &a)
+			So(a.ID, ShouldEqual, user.ID)
+			So(a.Bio, ShouldEqual, "Writes about Go.")
+			So(a.Page, ShouldEqual, 1)
+			So(a.Next, ShouldEqual, 0)
+			So(len(a.Posts), ShouldEqual, 1)
+			So(a.Posts[0].ID, ShouldEqual, post.ID)
+		})
+
+		Convey("page past the last one should return no posts", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", fmt.Sprintf("/api/author/%d?page=2", user.ID), nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			var u User
+			json.Unmarshal(recorder.Body.Bytes(), &u)
+			So(u.Posts, ShouldBeEmpty)
+		})
+
+		Convey("malformed page should return 400", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", fmt.Sprintf("/api/author/%d?page=foo", user.ID), nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 400)
+		})
+
+		Convey("non-existent author should return 404", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/author/999", nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 404)
+			So(recorder.Body.String(), ShouldEqual, `{"error":"Not found"}`)
+		})
+	})
+
+	Convey("using frontend", t, func() {
+
+		Convey("should display author's name and posts", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", fmt.Sprintf("/author/%d", user.ID), nil)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 200)
+			doc, _ := goquery.NewDocumentFromReader(recorder.Body)
+			So(doc.Find("section[role=author] h2").Text(), ShouldEqual, user.Name)
+			So(doc.Find("article .title").Text(), ShouldEqual, post.Title)
+		})
+	})
+}
+
+func TestPostSEO(t *testing.T) {
+
+	Convey("should return 401 without authorization", t, func() {
+		var recorder = httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", fmt.Sprintf("/api/post/%s/seo", post.Slug), nil)
+		server.ServeHTTP(recorder, request)
+		So(recorder.Code, ShouldEqual, 401)
+		So(recorder.Body.String(), ShouldEqual, `{"error":"Unauthorized"}`)
+	})
+
+	Convey("should return 404 with non-existent post", t, func() {
+		var recorder = httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", "/api/post/foobar/seo", nil)
+		cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+		request.AddCookie(cookie)
+		server.ServeHTTP(recorder, request)
+		So(recorder.Code, ShouldEqual, 404)
+		So(recorder.Body.String(), ShouldEqual, `{"error":"Not found"}`)
+	})
+
+	Convey("should return score breakdown with authorization", t, func() {
+		var recorder = httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", fmt.Sprintf("/api/post/%s/seo", post.Slug), nil)
+		cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+		request.AddCookie(cookie)
+		server.ServeHTTP(recorder, request)
+		So(recorder.Code, ShouldEqual, 200)
+		var seo SEO
+		json.Unmarshal(recorder.Body.Bytes(), &seo)
+		So(seo.Max, ShouldEqual, 100)
+		So(len(seo.Criteria), ShouldEqual, 5)
+		score := 0
+		for _, c := range seo.Criteria {
+			So(c.Rule, ShouldNotBeBlank)
+			So(c.Result, ShouldNotBeBlank)
+			score += c.Score
+		}
+		So(seo.Score, ShouldEqual, score)
+	})
+}
+
 func TestPostEditPage(t *testing.T) {
 
 	Convey("should return 200 OK with authorization", t, func() {
@@ -1087,6 +1311,137 @@ func TestMarkdown(t *testing.T) {
 	TestPublishPost(t)
 }
 
+func TestMarkdownLint(t *testing.T) {
+
+	Convey("linting post markdown", t, func() {
+
+		Convey("well formed markdown should return no warnings", func() {
+			var p Post
+			p.Markdown = "### foo\n\n```\ncode\n```\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n[foo][bar]\n\n[bar]: http://example.com"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("unclosed code fence should return a warning", func() {
+			var p Post
+			p.Markdown = "foo\n```go\nfmt.Println(\"foo\")"
+			So(p.Lint(), ShouldResemble, []string{"line 2: code fence ``` is never closed"})
+		})
+
+		Convey("undefined reference link should return a warning", func() {
+			var p Post
+			p.Markdown = "see [foo][bar]"
+			So(p.Lint(), ShouldResemble, []string{"line 1: reference link [bar] is not defined"})
+		})
+
+		Convey("table row with a wrong number of cells should return a warning", func() {
+			var p Post
+			p.Markdown = "| a | b |\n|---|---|\n| 1 | 2 | 3 |"
+			So(p.Lint(), ShouldResemble, []string{"line 3: table row has 3 columns, header has 2"})
+		})
+
+		Convey("paragraph or heading directly after a table should not return a warning", func() {
+			var p Post
+			p.Markdown = "| a | b |\n|---|---|\n| 1 | 2 |\nSome paragraph right after."
+			So(p.Lint(), ShouldBeEmpty)
+			p.Markdown = "| a | b |\n|---|---|\n| 1 | 2 |\n## Heading"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("brackets right after a word should not return a warning", func() {
+			var p Post
+			p.Markdown = "x[0][1] in prose"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("brackets inside code spans should not return a warning", func() {
+			var p Post
+			p.Markdown = "index with `grid[x][y]`"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("brackets inside indented code blocks should not return a warning", func() {
+			var p Post
+			p.Markdown = "code:\n\n    [foo][bar]\n\tgrid[x][y]"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("setext heading after a line with a pipe should not return a warning", func() {
+			var p Post
+			p.Markdown = "Use a | b.\n---"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+
+		Convey("pipes inside code spans and escaped pipes should not be counted as cells", func() {
+			var p Post
+			p.Markdown = "| a | b |\n|---|---|\n| `x|y` | 2 |\n| x \\| y | 2 |"
+			So(p.Lint(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestSEOScore(t *testing.T) {
+
+	criterion := func(p Post, name string) SEOCriterion {
+		seo, err := p.SEOScore()
+		So(err, ShouldBeNil)
+		for _, c := range seo.Criteria {
+			if c.Name == name {
+				return c
+			}
+		}
+		return SEOCriterion{}
+	}
+
+	Convey("scoring post SEO", t, func() {
+
+		Convey("title of 30 to 60 characters should get full score", func() {
+			var p Post
+			p.Title = "A title which is long enough for search"
+			So(criterion(p, "title").Score, ShouldEqual, 20)
+		})
+
+		Convey("title outside of 30 to 60 characters should not get full score", func() {
+			var p Post
+			p.Title = "Short title"
+			So(criterion(p, "title").Score, ShouldEqual, 10)
+			p.Title = "Foo"
+			So(criterion(p, "title").Score, ShouldEqual, 0)
+		})
+
+		Convey("subheadings without h1 should get full score", func() {
+			var p Post
+			p.Content = "<h2>foo</h2><p>bar</p>"
+			So(criterion(p, "headings").Score, ShouldEqual, 20)
+		})
+
+		Convey("h1 in content should get half score", func() {
+			var p Post
+			p.Content = "<h1>foo</h1><h2>bar</h2>"
+			So(criterion(p, "headings").Score, ShouldEqual, 10)
+		})
+
+		Convey("one of two images with alt text should get half score", func() {
+			var p Post
+			p.Content = `<p><img src="/a.png" alt="a"><img src="/b.png" alt=""></p>`
+			So(criterion(p, "image alt text").Score, ShouldEqual, 10)
+		})
+
+		Convey("links to this site should count as internal", func() {
+			var p Post
+			p.Content = `<a href="/post/foo">foo</a>`
+			So(criterion(p, "internal links").Score, ShouldEqual, 20)
+			p.Content = `<a href="https://example.com/post/foo">foo</a>`
+			So(criterion(p, "internal links").Score, ShouldEqual, 20)
+		})
+
+		Convey("links to other sites should not count as internal", func() {
+			var p Post
+			p.Content = `<a href="http://example.com.evil.org/foo">foo</a><a href="//evil.org/foo">foo</a><a href="#foo">foo</a>`
+			So(criterion(p, "internal links").Score, ShouldEqual, 0)
+		})
+	})
+}
+
 func TestFeeds(t *testing.T) {
 
 	Convey("reading feeds", t, func() {
@@ -1361,6 +1716,16 @@ func TestPostSecurity(t *testing.T) {
 			So(recorder.Body.String(), ShouldEqual, `{"error":"Unauthorized"}`)
 		})
 
+		Convey("reading SEO score of another user's post", func() {
+			var recorder = httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/post/"+post.Slug+"/seo", nil)
+			cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+			request.AddCookie(cookie)
+			server.ServeHTTP(recorder, request)
+			So(recorder.Code, ShouldEqual, 401)
+			So(recorder.Body.String(), ShouldEqual, `{"error":"Unauthorized"}`)
+		})
+
 		Convey("deleting post of another user", func() {
 			var recorder = httptest.NewRecorder()
 			request, _ := http.NewRequest("GET", "/api/post/"+post.Slug+"/delete", nil)
@@ -1374,6 +1739,36 @@ func TestPostSecurity(t *testing.T) {
 	})
 }
 
+func TestMarkdownWarnings(t *testing.T) {
+
+	var p Post
+
+	Convey("creating a post with an unclosed code fence should return warnings", t, func() {
+		var recorder = httptest.NewRecorder()
+		request, _ := http.NewRequest("POST", "/api/post", strings.NewReader(`{"title": "Lint post", "markdown": "foo\n`+"```"+`\nbar"}`))
+		cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+		request.AddCookie(cookie)
+		request.Header.Set("Content-Type", "application/json")
+		server.ServeHTTP(recorder, request)
+		So(recorder.Code, ShouldEqual, 200)
+		json.Unmarshal(recorder.Body.Bytes(), &p)
+		So(p.Warnings, ShouldNotBeEmpty)
+	})
+
+	Convey("updating a post with an unclosed code fence should return warnings", t, func() {
+		var recorder = httptest.NewRecorder()
+		request, _ := http.NewRequest("POST", fmt.Sprintf("/api/post/%s/edit", p.Slug), strings.NewReader(`{"title": "Lint post", "markdown": "foo\n`+"~~~"+`\nbaz"}`))
+		cookie := &http.Cookie{Name: "id", Value: sessioncookie}
+		request.AddCookie(cookie)
+		request.Header.Set("Content-Type", "application/json")
+		server.ServeHTTP(recorder, request)
+		So(recorder.Code, ShouldEqual, 200)
+		var updated Post
+		json.Unmarshal(recorder.Body.Bytes(), &updated)
+		So(updated.Warnings, ShouldNotBeEmpty)
+	})
+}
+
 func TestDropDatabase(t *testing.T) {
 	Drop()
 }
@@ -3,6 +3,7 @@ package render
 import (
 	"html/template"
 	"os"
+	"strings"
 	"time"
 
 	. "github.com/toldjuuso/vertigo/databases/sqlx"
@@ -38,11 +39,15 @@ var helpers = template.FuncMap{
 	},
 	// description renders page description.
 	// If none is defined, returns "Blog in Go" instead.
-	"description": func() string {
-		if Settings.Description == "" {
-			return "Blog in Go"
+	"description": description,
+	// metadescription renders post's Excerpt as the HTML document's meta description.
+	// On other pages and for empty excerpts it falls back to description.
+	"metadescription": func(t interface{}) string {
+		post, exists := t.(Post)
+		if exists && strings.TrimSpace(post.Excerpt) != "" {
+			return strings.TrimSpace(post.Excerpt)
 		}
-		return Settings.Description
+		return description()
 	},
 	// updated checks if post has been updated.
 	"updated": func(p Post) bool {
@@ -74,3 +79,11 @@ var helpers = template.FuncMap{
 		return Settings.AllowRegistrations
 	},
 }
+
+// description returns site description, or "Blog in Go" if none is defined.
+func description() string {
+	if Settings.Description == "" {
+		return "Blog in Go"
+	}
+	return Settings.Description
+}
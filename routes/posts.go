@@ -5,7 +5,9 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/toldjuuso/vertigo/databases/sqlx"
 	"github.com/toldjuuso/vertigo/render"
@@ -198,6 +200,43 @@ func ReadPosts(w http.ResponseWriter, r *http.Request) {
 	render.R.JSON(w, 200, published)
 }
 
+// ReadTrending is a route which returns published posts with the most views during a recent window.
+// The window defaults to 24 hours and can be set with URL query parameter "window", eg. "?window=6h".
+// The amount of posts defaults to 10 and can be set with URL query parameter "limit".
+// Only available for JSON API. See Post.GetTrending for more.
+func ReadTrending(w http.ResponseWriter, r *http.Request) {
+	window := 24 * time.Hour
+	if r.URL.Query().Get("window") != "" {
+		d, err := time.ParseDuration(r.URL.Query().Get("window"))
+		if err != nil || d <= 0 || d > ViewRetention {
+			log.Println("route ReadTrending, time.ParseDuration:", err)
+			render.R.JSON(w, 400, map[string]interface{}{"error": "The window could not be parsed from the request URL or it is longer than 720h."})
+			return
+		}
+		window = d
+	}
+
+	limit := 10
+	if r.URL.Query().Get("limit") != "" {
+		l, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || l < 1 {
+			log.Println("route ReadTrending, strconv.Atoi:", err)
+			render.R.JSON(w, 400, map[string]interface{}{"error": "The limit could not be parsed from the request URL."})
+			return
+		}
+		limit = l
+	}
+
+	var post Post
+	posts, err := post.GetTrending(window, limit)
+	if err != nil {
+		log.Println("route ReadTrending, post.GetTrending:", err)
+		render.R.JSON(w, 500, map[string]interface{}{"error": "Internal server error"})
+		return
+	}
+	render.R.JSON(w, 200, posts)
+}
+
 // ReadPost is a route which returns post with given post.Slug.
 // Returns post data on JSON call and displays a formatted page on frontend.
 func ReadPost(w http.ResponseWriter, r *http.Request) {
@@ -227,6 +266,45 @@ func ReadPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ReadPostSEO is a route which returns the heuristic SEO score of post with given post.Slug,
+// including a breakdown of every criterion and how it is scored. See Post.SEOScore for more.
+// Only available for JSON API. Requires active session cookie of the post's author.
+func ReadPostSEO(w http.ResponseWriter, r *http.Request) {
+	var post Post
+	post.Slug = vestigo.Param(r, "slug")
+	post, err := post.Get()
+	if err != nil {
+		log.Println("route ReadPostSEO, post.Get:", err)
+		if err.Error() == "not found" {
+			render.R.JSON(w, 404, map[string]interface{}{"error": "Not found"})
+			return
+		}
+		render.R.JSON(w, 500, map[string]interface{}{"error": "Internal server error"})
+		return
+	}
+
+	id, ok := SessionGetValue(r, "id")
+	if !ok {
+		log.Println("route ReadPostSEO, SessionGetValue:", ok)
+		SessionDelete(w, r, "id")
+		render.R.JSON(w, 401, map[string]interface{}{"error": "Unauthorized"})
+		return
+	}
+	if post.Author != id {
+		log.Println("route ReadPostSEO, author mismatch")
+		render.R.JSON(w, 401, map[string]interface{}{"error": "Unauthorized"})
+		return
+	}
+
+	seo, err := post.SEOScore()
+	if err != nil {
+		log.Println("route ReadPostSEO, post.SEOScore:", err)
+		render.R.JSON(w, 500, map[string]interface{}{"error": "Internal server error"})
+		return
+	}
+	render.R.JSON(w, 200, seo)
+}
+
 // EditPost is a route which returns a post object to be displayed and edited on frontend.
 // Not available for JSON API.
 // Analogous to ReadPost. Could be replaced at some point.
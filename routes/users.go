@@ -23,6 +23,26 @@ func GetUser(r *http.Request) (User, error) {
 	return rv.(User), nil
 }
 
+// Profile struct holds posted author profile fields for UpdateProfile.
+// Fields are pointers so that fields left out of the request can be told apart from emptied ones.
+type Profile struct {
+	Name    *string `json:"name" form:"name"`
+	Bio     *string `json:"bio" form:"bio"`
+	Avatar  *string `json:"avatar" form:"avatar"`
+	Website *string `json:"website" form:"website"`
+	Twitter *string `json:"twitter" form:"twitter"`
+	Github  *string `json:"github" form:"github"`
+}
+
+// GetProfile() returns binded Profile from POST data
+func GetProfile(r *http.Request) (Profile, error) {
+	rv, ok := context.GetOk(r, "profile")
+	if !ok {
+		return Profile{}, errors.New("context not set")
+	}
+	return rv.(Profile), nil
+}
+
 // CreateUser is a route which creates a new user struct according to posted parameters.
 // Requires session cookie.
 // Returns created user struct for API requests and redirects to "/user" on frontend ones.
@@ -160,6 +180,170 @@ func ReadUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// postsPerPage is the amount of posts listed on a single page of AuthorPage.
+const postsPerPage = 10
+
+// Author struct is a public author profile with a single page of the author's published posts.
+// It leaves out private User fields such as Email.
+// Previous and Next hold the neighbouring page numbers and are zero when there is no such page.
+type Author struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Bio      string `json:"bio"`
+	Avatar   string `json:"avatar"`
+	Website  string `json:"website"`
+	Twitter  string `json:"twitter"`
+	Github   string `json:"github"`
+	Posts    []Post `json:"posts"`
+	Page     int    `json:"page"`
+	Previous int    `json:"previous,omitempty"`
+	Next     int    `json:"next,omitempty"`
+}
+
+// AuthorPage is a route which fetches user according to parameter "id" and returns their profile
+// with published posts merged to object. Posts are paginated with URL query parameter "page".
+// Returns Author struct on API call. Frontend call will render author page, "author/index.tmpl".
+func AuthorPage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(vestigo.Param(r, "id"), 10, 64)
+	if err != nil {
+		log.Println("route AuthorPage, strconv.ParseInt:", err)
+		render.R.JSON(w, 400, map[string]interface{}{"error": "The user ID could not be parsed from the request URL."})
+		return
+	}
+
+	page := 1
+	if r.URL.Query().Get("page") != "" {
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			log.Println("route AuthorPage, strconv.Atoi:", err)
+			render.R.JSON(w, 400, map[string]interface{}{"error": "The page number could not be parsed from the request URL."})
+			return
+		}
+	}
+
+	var user User
+	user.ID = id
+	user, err = user.Profile()
+	if err != nil {
+		log.Println("route AuthorPage, user.Profile:", err)
+		if err.Error() == "not found" {
+			render.R.JSON(w, 404, map[string]interface{}{"error": "Not found"})
+			return
+		}
+		render.R.JSON(w, 500, map[string]interface{}{"error": "Internal server error"})
+		return
+	}
+
+	// one extra post is fetched to find out whether there is a next page
+	posts, err := user.PublishedPosts(postsPerPage+1, (page-1)*postsPerPage)
+	if err != nil {
+		log.Println("route AuthorPage, user.PublishedPosts:", err)
+		render.R.JSON(w, 500, map[string]interface{}{"error": "Internal server error"})
+		return
+	}
+
+	author := Author{
+		ID:      user.ID,
+		Name:    user.Name,
+		Bio:     user.Bio,
+		Avatar:  user.Avatar,
+		Website: user.Website,
+		Twitter: user.Twitter,
+		Github:  user.Github,
+		Page:    page,
+	}
+	if len(posts) > postsPerPage {
+		posts = posts[:postsPerPage]
+		author.Next = page + 1
+	}
+	if page > 1 {
+		author.Previous = page - 1
+	}
+	author.Posts = posts
+
+	switch Root(r) {
+	case "api":
+		render.R.JSON(w, 200, author)
+	case "author":
+		render.R.HTML(w, 200, "author/index", author)
+	}
+}
+
+// UpdateProfile is a route which updates the public author profile of the user according to session cookie.
+// Only Name, Bio, Avatar, Website, Twitter and Github fields are changed, and only when they are
+// present in the request. Name can not be emptied.
+// Returns updated user struct for API requests and redirects to "/user" on frontend ones.
+func UpdateProfile(w http.ResponseWriter, r *http.Request) {
+
+	profile, err := GetProfile(r)
+	if err != nil {
+		log.Println("route UpdateProfile, context GetProfile:", err)
+		profileError(w, r, 500, "Internal server error")
+		return
+	}
+
+	var user User
+	id, ok := SessionGetValue(r, "id")
+	if !ok {
+		log.Println("route UpdateProfile, SessionGetValue:", ok)
+		SessionDelete(w, r, "id")
+		profileError(w, r, 500, "Session could not be fetched. Please log in again.")
+		return
+	}
+	user.ID = id
+	user, err = user.Get()
+	if err != nil {
+		log.Println("route UpdateProfile, user.Get:", err)
+		SessionDelete(w, r, "id")
+		profileError(w, r, 500, "Session could not be fetched. Please log in again.")
+		return
+	}
+
+	entry := user
+	if profile.Name != nil && *profile.Name != "" {
+		entry.Name = *profile.Name
+	}
+	if profile.Bio != nil {
+		entry.Bio = *profile.Bio
+	}
+	if profile.Avatar != nil {
+		entry.Avatar = *profile.Avatar
+	}
+	if profile.Website != nil {
+		entry.Website = *profile.Website
+	}
+	if profile.Twitter != nil {
+		entry.Twitter = *profile.Twitter
+	}
+	if profile.Github != nil {
+		entry.Github = *profile.Github
+	}
+	user, err = user.Update(entry)
+	if err != nil {
+		log.Println("route UpdateProfile, user.Update:", err)
+		profileError(w, r, 500, "Internal server error")
+		return
+	}
+
+	switch Root(r) {
+	case "api":
+		render.R.JSON(w, 200, user)
+	case "user":
+		http.Redirect(w, r, "/user", 302)
+	}
+}
+
+// profileError renders error message of UpdateProfile as JSON on API calls and
+// with "error.tmpl" on frontend ones.
+func profileError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	switch Root(r) {
+	case "api":
+		render.R.JSON(w, status, map[string]interface{}{"error": message})
+	case "user":
+		render.R.HTML(w, status, "error", message)
+	}
+}
+
 // ReadUsers is a route only available on API side, which fetches all users with post data merged.
 // Returns complete list of users on success.
 func ReadUsers(w http.ResponseWriter, r *http.Request) {